@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+
+	"github.com/hemilabs/heminetwork/v2/hemi"
+)
+
+// MerkleProof is a compact inclusion proof: the sibling hash encountered at
+// each level of the tree, together with a bitfield recording whether the
+// sibling sat to the left or right of the node being proven.
+type MerkleProof struct {
+	Siblings []chainhash.Hash
+	// RightSibling[i] is true when Siblings[i] is the right-hand sibling of
+	// the node being hashed at level i (i.e. the node itself is the left
+	// child), false when the sibling is the left-hand one.
+	RightSibling []bool
+}
+
+// Bytes marshals the proof as a compact []byte: a one-byte sibling count, the
+// sibling hashes, and a bitfield (one bit per sibling, packed MSB-first).
+func (p MerkleProof) Bytes() []byte {
+	n := len(p.Siblings)
+	out := make([]byte, 0, 1+n*chainhash.HashSize+(n+7)/8)
+	out = append(out, byte(n))
+	for _, s := range p.Siblings {
+		out = append(out, s[:]...)
+	}
+
+	bitfield := make([]byte, (n+7)/8)
+	for i, right := range p.RightSibling {
+		if right {
+			bitfield[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+	out = append(out, bitfield...)
+
+	return out
+}
+
+// ParseMerkleProof is the inverse of MerkleProof.Bytes.
+func ParseMerkleProof(b []byte) (MerkleProof, error) {
+	if len(b) < 1 {
+		return MerkleProof{}, fmt.Errorf("short proof: %d bytes", len(b))
+	}
+	n := int(b[0])
+	b = b[1:]
+
+	wantSiblings := n * chainhash.HashSize
+	wantBitfield := (n + 7) / 8
+	if len(b) != wantSiblings+wantBitfield {
+		return MerkleProof{}, fmt.Errorf("malformed proof: have %d bytes, want %d", len(b), wantSiblings+wantBitfield)
+	}
+
+	proof := MerkleProof{
+		Siblings:     make([]chainhash.Hash, n),
+		RightSibling: make([]bool, n),
+	}
+	for i := 0; i < n; i++ {
+		copy(proof.Siblings[i][:], b[i*chainhash.HashSize:(i+1)*chainhash.HashSize])
+	}
+
+	bitfield := b[wantSiblings:]
+	for i := 0; i < n; i++ {
+		proof.RightSibling[i] = bitfield[i/8]&(1<<uint(7-i%8)) != 0
+	}
+
+	return proof, nil
+}
+
+// KeystoneMerkleTree is a simple SHA-256 Merkle tree over the abbreviated
+// hashes of a batch of L2Keystones. Odd levels duplicate their last leaf,
+// matching Bitcoin's convention so results are cross-verifiable with
+// chainhash.
+type KeystoneMerkleTree struct {
+	levels [][]chainhash.Hash
+}
+
+// BuildKeystoneMerkleTree builds a KeystoneMerkleTree over kss, in order.
+func BuildKeystoneMerkleTree(kss []hemi.L2Keystone) *KeystoneMerkleTree {
+	leaves := make([]chainhash.Hash, len(kss))
+	for i, ks := range kss {
+		leaves[i] = *hemi.L2KeystoneAbbreviate(ks).Hash()
+	}
+
+	return buildKeystoneMerkleTree(leaves)
+}
+
+func buildKeystoneMerkleTree(leaves []chainhash.Hash) *KeystoneMerkleTree {
+	levels := [][]chainhash.Hash{leaves}
+	for level := leaves; len(level) > 1; {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]chainhash.Hash, len(level)/2)
+		for i := range next {
+			next[i] = merkleParent(level[2*i], level[2*i+1])
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &KeystoneMerkleTree{levels: levels}
+}
+
+func merkleParent(left, right chainhash.Hash) chainhash.Hash {
+	var buf [2 * chainhash.HashSize]byte
+	copy(buf[:chainhash.HashSize], left[:])
+	copy(buf[chainhash.HashSize:], right[:])
+	return chainhash.DoubleHashH(buf[:])
+}
+
+// Root returns the tree's Merkle root. It returns the zero hash for an empty
+// tree.
+func (t *KeystoneMerkleTree) Root() chainhash.Hash {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return chainhash.Hash{}
+	}
+	return top[0]
+}
+
+// Proof returns the inclusion proof for the leaf at index.
+func (t *KeystoneMerkleTree) Proof(index int) MerkleProof {
+	var proof MerkleProof
+
+	idx := index
+	for _, level := range t.levels[:len(t.levels)-1] {
+		siblingIdx := idx ^ 1
+		// The last leaf of an odd-length level was duplicated to form its
+		// own sibling; reflect that here rather than indexing past the end.
+		if siblingIdx >= len(level) {
+			siblingIdx = idx
+		}
+		proof.Siblings = append(proof.Siblings, level[siblingIdx])
+		proof.RightSibling = append(proof.RightSibling, idx%2 == 0)
+		idx /= 2
+	}
+
+	return proof
+}
+
+// VerifyKeystoneProof reports whether proof proves that leaf is included in
+// the tree with the given root.
+func VerifyKeystoneProof(root chainhash.Hash, leaf *hemi.L2KeystoneAbrev, proof MerkleProof) bool {
+	if len(proof.Siblings) != len(proof.RightSibling) {
+		return false
+	}
+
+	hash := *leaf.Hash()
+	for i, sibling := range proof.Siblings {
+		if proof.RightSibling[i] {
+			hash = merkleParent(hash, sibling)
+		} else {
+			hash = merkleParent(sibling, hash)
+		}
+	}
+
+	return hash == root
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hemilabs/heminetwork/v2/hemi"
+)
+
+func TestKeystoneMerkleTreeRoundTrip(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 5} {
+		_, kssList := MakeSharedKeystones(n)
+
+		tree := BuildKeystoneMerkleTree(kssList)
+		root := tree.Root()
+
+		for i, ks := range kssList {
+			proof := tree.Proof(i)
+			leaf := hemi.L2KeystoneAbbreviate(ks)
+
+			if !VerifyKeystoneProof(root, leaf, proof) {
+				t.Fatalf("n=%d: VerifyKeystoneProof(index %d) = false, want true", n, i)
+			}
+
+			encoded := proof.Bytes()
+			decoded, err := ParseMerkleProof(encoded)
+			if err != nil {
+				t.Fatalf("n=%d: ParseMerkleProof(index %d): %v", n, i, err)
+			}
+			if !reflect.DeepEqual(proof, decoded) {
+				t.Fatalf("n=%d: proof %d did not round-trip through Bytes/ParseMerkleProof: %#v != %#v",
+					n, i, proof, decoded)
+			}
+			if !VerifyKeystoneProof(root, leaf, decoded) {
+				t.Fatalf("n=%d: VerifyKeystoneProof(index %d) with round-tripped proof = false, want true", n, i)
+			}
+		}
+	}
+}
+
+func TestKeystoneMerkleTreeRejectsWrongLeaf(t *testing.T) {
+	_, kssList := MakeSharedKeystones(4)
+	tree := BuildKeystoneMerkleTree(kssList)
+	root := tree.Root()
+
+	proof := tree.Proof(0)
+	wrongLeaf := hemi.L2KeystoneAbbreviate(kssList[1])
+	if VerifyKeystoneProof(root, wrongLeaf, proof) {
+		t.Errorf("VerifyKeystoneProof() = true for a leaf/proof mismatch, want false")
+	}
+}
+
+func TestKeystoneMerkleTreeRejectsTamperedProof(t *testing.T) {
+	_, kssList := MakeSharedKeystones(4)
+	tree := BuildKeystoneMerkleTree(kssList)
+	root := tree.Root()
+
+	proof := tree.Proof(0)
+	leaf := hemi.L2KeystoneAbbreviate(kssList[0])
+	proof.Siblings[0][0] ^= 0xff
+
+	if VerifyKeystoneProof(root, leaf, proof) {
+		t.Errorf("VerifyKeystoneProof() = true for a tampered sibling, want false")
+	}
+}
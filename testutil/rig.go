@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/hemilabs/heminetwork/v2/hemi"
+)
+
+// DerivedKey is a BIP44-derived private key together with its P2PKH address.
+type DerivedKey struct {
+	PrivateKey *btcec.PrivateKey
+	Address    *btcutil.AddressPubKeyHash
+}
+
+// Rig is a deterministic source of BTC private keys and L2Keystone fixtures,
+// seeded from a single BIP39 mnemonic so that a fixture set can be
+// regenerated identically across machines and CI shards.
+type Rig struct {
+	seed   []byte
+	params *chaincfg.Params
+}
+
+// NewDeterministicRig turns mnemonic into a BIP39 seed (PBKDF2-HMAC-SHA512,
+// 2048 iterations, salt "mnemonic"+passphrase) and returns a Rig that
+// derives BTC keys and L2Keystone fixtures from it.
+func NewDeterministicRig(mnemonic, passphrase string) (*Rig, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("derive seed: %w", err)
+	}
+
+	return &Rig{seed: seed, params: &chaincfg.MainNetParams}, nil
+}
+
+// GenerateMnemonic returns a new random BIP39 mnemonic with entropyBits bits
+// of entropy (must be a multiple of 32, between 128 and 256).
+func GenerateMnemonic(entropyBits int) (string, error) {
+	entropy, err := bip39.NewEntropy(entropyBits)
+	if err != nil {
+		return "", fmt.Errorf("generate entropy: %w", err)
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("generate mnemonic: %w", err)
+	}
+
+	return mnemonic, nil
+}
+
+// Derive walks the BIP44-style path m/44'/0'/account'/change/index from the
+// rig's seed and returns the resulting private key and P2PKH address.
+func (r *Rig) Derive(account, change, index uint32) (*DerivedKey, error) {
+	master, err := hdkeychain.NewMaster(r.seed, r.params)
+	if err != nil {
+		return nil, fmt.Errorf("master key: %w", err)
+	}
+
+	key := master
+	for _, childIndex := range []uint32{
+		44 + hdkeychain.HardenedKeyStart,
+		0 + hdkeychain.HardenedKeyStart,
+		account + hdkeychain.HardenedKeyStart,
+		change,
+		index,
+	} {
+		key, err = key.Derive(childIndex)
+		if err != nil {
+			return nil, fmt.Errorf("derive child %d: %w", childIndex, err)
+		}
+	}
+
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("ec private key: %w", err)
+	}
+
+	addr, err := btcutil.NewAddressPubKeyHash(
+		btcutil.Hash160(privKey.PubKey().SerializeCompressed()), r.params)
+	if err != nil {
+		return nil, fmt.Errorf("derive address: %w", err)
+	}
+
+	return &DerivedKey{PrivateKey: privKey, Address: addr}, nil
+}
+
+// Keystones derives n BIP44 keys (account 0, change 0, indices 0..n-1) and
+// mixes the derived key material into a matching set of L2Keystone fixtures,
+// so the resulting keystones are reproducible from the rig's mnemonic alone.
+func (r *Rig) Keystones(n int) ([]hemi.L2Keystone, error) {
+	kssList := make([]hemi.L2Keystone, 0, n)
+
+	prevEPHash := SHA256([]byte("genesis"))
+	for i := 0; i < n; i++ {
+		derived, err := r.Derive(0, 0, uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("derive keystone %d: %w", i, err)
+		}
+
+		pubKeyBytes := derived.PrivateKey.PubKey().SerializeCompressed()
+		l2Keystone := hemi.L2Keystone{
+			Version:            1,
+			L1BlockNumber:      10000 + uint32(i),
+			L2BlockNumber:      uint32(i+1) * 25,
+			ParentEPHash:       SHA256(pubKeyBytes),
+			PrevKeystoneEPHash: prevEPHash,
+			StateRoot:          SHA256(append([]byte("stateroot"), pubKeyBytes...)),
+			EPHash:             SHA256(append([]byte("ephash"), pubKeyBytes...)),
+		}
+		kssList = append(kssList, l2Keystone)
+		prevEPHash = l2Keystone.EPHash
+	}
+
+	return kssList, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// knownMnemonic and knownSeedHex are the canonical BIP39 test vector from
+// https://github.com/trezor/python-mnemonic/blob/master/vectors.json.
+const (
+	knownMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	knownPassword = "TREZOR"
+	knownSeedHex  = "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+)
+
+func TestMnemonicToSeedVector(t *testing.T) {
+	got, err := bip39.NewSeedWithErrorChecking(knownMnemonic, knownPassword)
+	if err != nil {
+		t.Fatalf("NewSeedWithErrorChecking() = %v", err)
+	}
+	want, err := hex.DecodeString(knownSeedHex)
+	if err != nil {
+		t.Fatalf("decode want: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSeedWithErrorChecking() = %x, want %x", got, want)
+	}
+}
+
+func TestNewDeterministicRigDeterministic(t *testing.T) {
+	rigA, err := NewDeterministicRig(knownMnemonic, knownPassword)
+	if err != nil {
+		t.Fatalf("NewDeterministicRig() rigA: %v", err)
+	}
+	rigB, err := NewDeterministicRig(knownMnemonic, knownPassword)
+	if err != nil {
+		t.Fatalf("NewDeterministicRig() rigB: %v", err)
+	}
+
+	derivedA, err := rigA.Derive(0, 0, 0)
+	if err != nil {
+		t.Fatalf("rigA.Derive() = %v", err)
+	}
+	derivedB, err := rigB.Derive(0, 0, 0)
+	if err != nil {
+		t.Fatalf("rigB.Derive() = %v", err)
+	}
+	if !reflect.DeepEqual(derivedA.PrivateKey.Serialize(), derivedB.PrivateKey.Serialize()) {
+		t.Errorf("Derive() private keys differ across rigs built from the same mnemonic")
+	}
+	if derivedA.Address.EncodeAddress() != derivedB.Address.EncodeAddress() {
+		t.Errorf("Derive() addresses differ: %v != %v", derivedA.Address, derivedB.Address)
+	}
+
+	kssA, err := rigA.Keystones(3)
+	if err != nil {
+		t.Fatalf("rigA.Keystones() = %v", err)
+	}
+	kssB, err := rigB.Keystones(3)
+	if err != nil {
+		t.Fatalf("rigB.Keystones() = %v", err)
+	}
+	if !reflect.DeepEqual(kssA, kssB) {
+		t.Errorf("Keystones() not reproducible from the same mnemonic:\n%#v\n%#v", kssA, kssB)
+	}
+}
+
+func TestGenerateMnemonic(t *testing.T) {
+	m1, err := GenerateMnemonic(128)
+	if err != nil {
+		t.Fatalf("GenerateMnemonic(128) = %v", err)
+	}
+	if !bip39.IsMnemonicValid(m1) {
+		t.Errorf("GenerateMnemonic(128) produced an invalid mnemonic: %v", m1)
+	}
+	if n := len(strings.Fields(m1)); n != 12 {
+		t.Errorf("GenerateMnemonic(128) produced %d words, want 12", n)
+	}
+
+	m2, err := GenerateMnemonic(128)
+	if err != nil {
+		t.Fatalf("GenerateMnemonic(128) = %v", err)
+	}
+	if m1 == m2 {
+		t.Errorf("GenerateMnemonic(128) produced the same mnemonic twice: %v", m1)
+	}
+
+	if _, err := GenerateMnemonic(100); err == nil {
+		t.Errorf("GenerateMnemonic(100) should reject a non-multiple-of-32 entropy size")
+	}
+}
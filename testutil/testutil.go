@@ -17,7 +17,6 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/btcutil"
-	"github.com/btcsuite/btcd/txscript"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -111,39 +110,23 @@ func RandomBytes(count int) []byte {
 	return b
 }
 
-// ExecuteTX executes a bitcoin transaction script against a VM and optionally dumps steps.
-// This consolidates the frequently used executeTX helper into testutil.
+// ExecuteTX executes a bitcoin transaction script against a VM and optionally
+// dumps steps. This consolidates the frequently used executeTX helper into
+// testutil. It is a thin pretty-printer over ExecuteTXTrace; use
+// ExecuteTXTrace directly for programmatic assertions on where a script
+// failed.
 func ExecuteTX(t testing.TB, dump bool, scriptPubKey []byte, tx *btcutil.Tx) error {
-	flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
-		txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
-	vm, err := txscript.NewEngine(scriptPubKey, tx.MsgTx(), 0, flags, nil, nil, -1, nil)
-	if err != nil {
-		return err
-	}
 	if dump {
 		t.Logf("=== executing tx %v", tx.Hash())
 	}
-	for i := 0; ; i++ {
-		d, err := vm.DisasmPC()
-		if err != nil {
-			return err
-		}
-		if dump {
-			t.Logf("%v: %v", i, d)
-		}
-		done, err := vm.Step()
-		if err != nil {
-			return err
-		}
-		stack := vm.GetStack()
-		if dump {
-			t.Logf("%v: stack %v", i, spew.Sdump(stack))
-		}
-		if done {
-			break
+
+	trace, err := ExecuteTXTrace(scriptPubKey, tx)
+	if dump && trace != nil {
+		for _, step := range trace.Steps {
+			t.Logf("%v: %v", step.PC, step.Op)
+			t.Logf("%v: stack %v", step.PC, spew.Sdump(step.StackAfter))
 		}
 	}
-	err = vm.CheckErrorCondition(true)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ExecutionStep is a single txscript VM step: the opcode about to execute at
+// PC, and the main/alt stacks before and after it ran.
+type ExecutionStep struct {
+	PC          int
+	Op          string
+	StackBefore [][]byte
+	StackAfter  [][]byte
+	AltStack    [][]byte
+}
+
+// ExecutionTrace is the full step-by-step record of a script execution,
+// suitable for programmatic assertions instead of eyeballing log spew.
+type ExecutionTrace struct {
+	Steps []ExecutionStep
+}
+
+// ExecuteTXTrace executes a bitcoin transaction script against a VM and
+// returns the full execution trace. If the script fails, the trace returned
+// covers every step executed before the failure.
+func ExecuteTXTrace(scriptPubKey []byte, tx *btcutil.Tx) (*ExecutionTrace, error) {
+	flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
+		txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
+	vm, err := txscript.NewEngine(scriptPubKey, tx.MsgTx(), 0, flags, nil, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &ExecutionTrace{}
+	for i := 0; ; i++ {
+		op, err := vm.DisasmPC()
+		if err != nil {
+			return trace, err
+		}
+		stackBefore := copyStack(vm.GetStack())
+
+		done, err := vm.Step()
+		if err != nil {
+			return trace, err
+		}
+
+		trace.Steps = append(trace.Steps, ExecutionStep{
+			PC:          i,
+			Op:          op,
+			StackBefore: stackBefore,
+			StackAfter:  copyStack(vm.GetStack()),
+			AltStack:    copyStack(vm.GetAltStack()),
+		})
+		if done {
+			break
+		}
+	}
+
+	if err := vm.CheckErrorCondition(true); err != nil {
+		return trace, err
+	}
+
+	return trace, nil
+}
+
+func copyStack(stack [][]byte) [][]byte {
+	out := make([][]byte, len(stack))
+	for i, v := range stack {
+		out[i] = append([]byte(nil), v...)
+	}
+	return out
+}
+
+// AssertFinalStackTrue fails t unless the trace's last step left a single
+// canonically-true value on top of the stack.
+func (tr *ExecutionTrace) AssertFinalStackTrue(t testing.TB) {
+	t.Helper()
+
+	if len(tr.Steps) == 0 {
+		t.Fatalf("execution trace has no steps")
+	}
+
+	final := tr.Steps[len(tr.Steps)-1].StackAfter
+	if len(final) == 0 || !scriptBool(final[len(final)-1]) {
+		t.Fatalf("final stack is not true: %x", final)
+	}
+}
+
+// scriptBool applies Bitcoin Script's truth rule: a value is true unless it
+// is all-zero, allowing for a single trailing negative-zero byte (0x80).
+func scriptBool(v []byte) bool {
+	for i, b := range v {
+		if b == 0 {
+			continue
+		}
+		if i == len(v)-1 && b == 0x80 {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// FindOp returns the PCs of every step whose disassembled opcode contains
+// name, e.g. Trace.FindOp("OP_CHECKSIG").
+func (tr *ExecutionTrace) FindOp(name string) []int {
+	var pcs []int
+	for _, step := range tr.Steps {
+		if strings.Contains(step.Op, name) {
+			pcs = append(pcs, step.PC)
+		}
+	}
+	return pcs
+}
+
+// JSON marshals the trace for use as a golden file.
+func (tr *ExecutionTrace) JSON() ([]byte, error) {
+	return json.MarshalIndent(tr, "", "  ")
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestExecuteTXTraceP2PKH(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+
+	prevOutput := SpendableOutput{
+		Outpoint: wire.OutPoint{Index: 0},
+		PkScript: p2pkhScript(t, key),
+		Value:    100_000_000,
+	}
+	tx := BuildSignedTx(t, []SpendableOutput{prevOutput},
+		[]wire.TxOut{{Value: prevOutput.Value, PkScript: prevOutput.PkScript}},
+		key, txscript.SigHashAll)
+
+	trace, err := ExecuteTXTrace(prevOutput.PkScript, tx)
+	if err != nil {
+		t.Fatalf("ExecuteTXTrace() = %v, want nil", err)
+	}
+
+	trace.AssertFinalStackTrue(t)
+
+	pcs := trace.FindOp("OP_CHECKSIG")
+	if len(pcs) != 1 {
+		t.Fatalf("FindOp(OP_CHECKSIG) = %v, want exactly one match", pcs)
+	}
+
+	encoded, err := trace.JSON()
+	if err != nil {
+		t.Fatalf("JSON() = %v", err)
+	}
+	var decoded ExecutionTrace
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal(JSON()) = %v", err)
+	}
+	if !reflect.DeepEqual(*trace, decoded) {
+		t.Errorf("trace did not round-trip through JSON()/Unmarshal: %#v != %#v", *trace, decoded)
+	}
+}
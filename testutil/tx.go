@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SpendableOutput is a previous transaction output that can be used as the
+// input to a new transaction built by BuildSignedTx.
+type SpendableOutput struct {
+	Outpoint wire.OutPoint
+	PkScript []byte
+	Value    int64
+}
+
+// Signer produces a signature script for a single transaction input. It is
+// the extension point used by BuildSignedTx so that callers can plug in
+// P2SH/multisig signing without BuildSignedTx needing to know about it.
+type Signer interface {
+	SignInput(tx *wire.MsgTx, idx int, prevOutput SpendableOutput) ([]byte, error)
+}
+
+// P2PKHSigner signs P2PKH inputs with a single private key.
+type P2PKHSigner struct {
+	Key      *btcec.PrivateKey
+	HashType txscript.SigHashType
+}
+
+// SignInput implements Signer.
+func (s P2PKHSigner) SignInput(tx *wire.MsgTx, idx int, prevOutput SpendableOutput) ([]byte, error) {
+	return txscript.SignatureScript(tx, idx, prevOutput.PkScript, s.HashType, s.Key, true)
+}
+
+// P2SHMultisigSigner signs P2SH bare-multisig inputs, producing a sigScript
+// of the form OP_0 <sig1> <sig2> ... <RedeemScript>. RedeemScript is the
+// CHECKMULTISIG redeem script hashed into the P2SH output being spent; Keys
+// must hold at least the number of signatures it requires, in the order it
+// expects them.
+type P2SHMultisigSigner struct {
+	RedeemScript []byte
+	Keys         []*btcec.PrivateKey
+	HashType     txscript.SigHashType
+}
+
+// SignInput implements Signer.
+func (s P2SHMultisigSigner) SignInput(tx *wire.MsgTx, idx int, prevOutput SpendableOutput) ([]byte, error) {
+	builder := txscript.NewScriptBuilder().AddOp(txscript.OP_0)
+	for _, key := range s.Keys {
+		sig, err := txscript.RawTxInSignature(tx, idx, s.RedeemScript, s.HashType, key)
+		if err != nil {
+			return nil, fmt.Errorf("sign with key: %w", err)
+		}
+		builder.AddData(sig)
+	}
+	builder.AddData(s.RedeemScript)
+
+	return builder.Script()
+}
+
+// BuildSignedTx assembles a transaction spending prevOutputs into outputs and
+// signs every P2PKH input with key, returning the fully signed transaction.
+// Use BuildSignedTxWithSigner to sign with a P2SH/multisig Signer instead.
+func BuildSignedTx(t testing.TB, prevOutputs []SpendableOutput, outputs []wire.TxOut, key *btcec.PrivateKey, hashType txscript.SigHashType) *btcutil.Tx {
+	t.Helper()
+
+	return BuildSignedTxWithSigner(t, prevOutputs, outputs, P2PKHSigner{Key: key, HashType: hashType})
+}
+
+// BuildSignedTxWithSigner is the pluggable variant of BuildSignedTx: it
+// assembles a transaction spending prevOutputs into outputs and signs every
+// input with signer, returning the fully signed transaction.
+func BuildSignedTxWithSigner(t testing.TB, prevOutputs []SpendableOutput, outputs []wire.TxOut, signer Signer) *btcutil.Tx {
+	t.Helper()
+
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	for _, prevOutput := range prevOutputs {
+		msgTx.AddTxIn(wire.NewTxIn(&prevOutput.Outpoint, nil, nil))
+	}
+	for _, output := range outputs {
+		output := output
+		msgTx.AddTxOut(&output)
+	}
+
+	for idx, prevOutput := range prevOutputs {
+		sigScript, err := signer.SignInput(msgTx, idx, prevOutput)
+		if err != nil {
+			t.Fatalf("sign input %d: %v", idx, err)
+		}
+		msgTx.TxIn[idx].SignatureScript = sigScript
+	}
+
+	return btcutil.NewTx(msgTx)
+}
+
+// CreateTxChain returns n chained, fully-signed, zero-fee transactions. Each
+// transaction spends the sole output of the previous one, mirroring the
+// chained-transaction pattern used by btcd's mempool test harness. The first
+// transaction spends firstOutput.
+func CreateTxChain(t testing.TB, firstOutput SpendableOutput, key *btcec.PrivateKey, n int) []*btcutil.Tx {
+	t.Helper()
+
+	chain := make([]*btcutil.Tx, 0, n)
+	prevOutput := firstOutput
+	for i := 0; i < n; i++ {
+		tx := BuildSignedTx(t, []SpendableOutput{prevOutput},
+			[]wire.TxOut{{Value: prevOutput.Value, PkScript: prevOutput.PkScript}}, key, txscript.SigHashAll)
+		chain = append(chain, tx)
+
+		prevOutput = SpendableOutput{
+			Outpoint: wire.OutPoint{Hash: *tx.Hash(), Index: 0},
+			PkScript: prevOutput.PkScript,
+			Value:    prevOutput.Value,
+		}
+	}
+
+	return chain
+}
+
+// ExecuteTXChain runs ExecuteTX over every transaction in chain, wiring each
+// transaction's sole txout pkScript into the execution of the transaction
+// that spends it. The first transaction in the chain is executed against
+// its own output pkScript, since CreateTxChain carries the same pkScript
+// through every link.
+func ExecuteTXChain(t testing.TB, dump bool, chain []*btcutil.Tx) error {
+	t.Helper()
+
+	prevScript := []byte(nil)
+	for i, tx := range chain {
+		if len(tx.MsgTx().TxOut) == 0 {
+			return fmt.Errorf("tx %d: no outputs to spend", i)
+		}
+		if i == 0 {
+			prevScript = tx.MsgTx().TxOut[0].PkScript
+		}
+		if err := ExecuteTX(t, dump, prevScript, tx); err != nil {
+			return fmt.Errorf("tx %d: %w", i, err)
+		}
+		prevScript = tx.MsgTx().TxOut[0].PkScript
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Hemi Labs, Inc.
+// Use of this source code is governed by the MIT License,
+// which can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func p2pkhScript(t testing.TB, key *btcec.PrivateKey) []byte {
+	t.Helper()
+
+	addr, err := btcutil.NewAddressPubKeyHash(
+		btcutil.Hash160(key.PubKey().SerializeCompressed()), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressPubKeyHash() = %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("PayToAddrScript() = %v", err)
+	}
+	return script
+}
+
+func TestCreateTxChainExecutes(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+
+	firstOutput := SpendableOutput{
+		Outpoint: wire.OutPoint{Index: 0},
+		PkScript: p2pkhScript(t, key),
+		Value:    100_000_000,
+	}
+
+	chain := CreateTxChain(t, firstOutput, key, 5)
+	if len(chain) != 5 {
+		t.Fatalf("CreateTxChain() returned %d transactions, want 5", len(chain))
+	}
+
+	if err := ExecuteTXChain(t, false, chain); err != nil {
+		t.Errorf("ExecuteTXChain() = %v, want nil", err)
+	}
+}
+
+func TestCreateTxChainWrongKeyFails(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+	wrongKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+
+	firstOutput := SpendableOutput{
+		Outpoint: wire.OutPoint{Index: 0},
+		PkScript: p2pkhScript(t, key),
+		Value:    100_000_000,
+	}
+
+	tx := BuildSignedTx(t, []SpendableOutput{firstOutput},
+		[]wire.TxOut{{Value: firstOutput.Value, PkScript: firstOutput.PkScript}},
+		wrongKey, txscript.SigHashAll)
+
+	if err := ExecuteTX(t, false, firstOutput.PkScript, tx); err == nil {
+		t.Errorf("ExecuteTX() = nil, want an error for a transaction signed with the wrong key")
+	}
+}
+
+func TestBuildSignedTxWithSignerP2SHMultisig(t *testing.T) {
+	key1, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+	key2, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey() = %v", err)
+	}
+
+	addrKey1, err := btcutil.NewAddressPubKey(key1.PubKey().SerializeCompressed(), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressPubKey() = %v", err)
+	}
+	addrKey2, err := btcutil.NewAddressPubKey(key2.PubKey().SerializeCompressed(), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressPubKey() = %v", err)
+	}
+
+	redeemScript, err := txscript.MultiSigScript(
+		[]*btcutil.AddressPubKey{addrKey1, addrKey2}, 2)
+	if err != nil {
+		t.Fatalf("MultiSigScript() = %v", err)
+	}
+
+	scriptAddr, err := btcutil.NewAddressScriptHash(redeemScript, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressScriptHash() = %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(scriptAddr)
+	if err != nil {
+		t.Fatalf("PayToAddrScript() = %v", err)
+	}
+
+	prevOutput := SpendableOutput{
+		Outpoint: wire.OutPoint{Index: 0},
+		PkScript: pkScript,
+		Value:    100_000_000,
+	}
+	signer := P2SHMultisigSigner{
+		RedeemScript: redeemScript,
+		Keys:         []*btcec.PrivateKey{key1, key2},
+		HashType:     txscript.SigHashAll,
+	}
+
+	tx := BuildSignedTxWithSigner(t, []SpendableOutput{prevOutput},
+		[]wire.TxOut{{Value: prevOutput.Value, PkScript: prevOutput.PkScript}}, signer)
+
+	if err := ExecuteTX(t, false, prevOutput.PkScript, tx); err != nil {
+		t.Errorf("ExecuteTX() = %v, want nil for a valid 2-of-2 multisig spend", err)
+	}
+}